@@ -0,0 +1,25 @@
+package dice
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+type cryptoRand struct{}
+
+// CryptoRand is a Rand implementation backed by crypto/rand, suitable for
+// security-sensitive uses such as loot generation in adversarial contexts
+// where a predictable math/rand sequence would be exploitable. It is safe
+// for concurrent use since crypto/rand.Reader is itself safe for
+// concurrent use.
+var CryptoRand Rand = cryptoRand{}
+
+// Intn returns a cryptographically secure random int from [0, n).
+func (r cryptoRand) Intn(n int) int {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(fmt.Sprintf("dice: crypto/rand.Int failed: %v", err))
+	}
+	return int(v.Int64())
+}
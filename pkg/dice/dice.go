@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"sync"
 )
 
 // Dice is a struct representing a set of similar dice which may be rolled.
@@ -12,6 +13,10 @@ type Dice struct {
 	Sides    int
 	DropLow  int
 	DropHigh int
+
+	distOnce sync.Once
+	dist     *Distribution
+	distErr  error
 }
 
 // Results is the set of results from a dice roll.
@@ -70,19 +75,19 @@ func (d *Dice) String() string {
 // panic.
 func (d *Dice) Validate() error {
 	if d.Number < 1 {
-		return ErrNumberTooLow(d.Number)
+		return &NumberTooLowError{N: d.Number}
 	}
 	if d.Sides <= 1 {
-		return ErrSidesTooLow(d.Sides)
+		return &SidesTooLowError{N: d.Sides}
 	}
 	if d.DropLow < 0 {
-		return ErrDropLowTooLow(d.DropLow)
+		return &DropLowTooLowError{N: d.DropLow}
 	}
 	if d.DropHigh < 0 {
-		return ErrDropHighTooLow(d.DropHigh)
+		return &DropHighTooLowError{N: d.DropHigh}
 	}
 	if d.DropLow+d.DropHigh >= d.Number {
-		return ErrTooManyDropped(d.DropLow, d.DropHigh, d.Number)
+		return &TooManyDroppedError{Low: d.DropLow, High: d.DropHigh, Number: d.Number}
 	}
 
 	return nil
@@ -106,17 +111,37 @@ func (d *Dice) RollRand(r Rand) *big.Int {
 //
 // This function assumes that the Dice instance is valid - if it isn't, the
 // roll may cause a panic (e.g. if you have negatives somewhere).
+//
+// This function is equivalent to calling `d.RollResultsHook(r, h)` with h
+// being the package-level default Hook set by SetDefaultHook.
 func (d *Dice) RollResults(r Rand) *Results {
+	return d.RollResultsHook(r, defaultHook)
+}
+
+// RollResultsHook simulates a dice roll as specified by the Dice instance
+// with the given Rand implementation, notifying the given Hook of each step
+// of the roll as it happens. Passing nil for h is equivalent to NopHook{}.
+//
+// This function assumes that the Dice instance is valid - if it isn't, the
+// roll may cause a panic (e.g. if you have negatives somewhere).
+func (d *Dice) RollResultsHook(r Rand, h Hook) *Results {
+	if h == nil {
+		h = NopHook{}
+	}
+
 	results := &Results{
 		Value: big.NewInt(0),
 		Raw:   make([]int, 0, d.Number),
 	}
 
 	for i := 0; i < d.Number; i++ {
-		results.Raw = append(results.Raw, r.Intn(d.Sides)+1)
+		value := r.Intn(d.Sides) + 1
+		h.OnDie(i, d.Sides, value)
+		results.Raw = append(results.Raw, value)
 	}
 
 	sort.Ints(results.Raw)
+	h.OnSort(results.Raw)
 
 	if d.DropLow > 0 {
 		results.DroppedLow = results.Raw[:d.DropLow]
@@ -125,12 +150,14 @@ func (d *Dice) RollResults(r Rand) *Results {
 		results.DroppedHigh = results.Raw[d.Number-d.DropHigh:]
 	}
 	results.Kept = results.Raw[d.DropLow : d.Number-d.DropHigh]
+	h.OnDrop(results.DroppedLow, results.DroppedHigh)
 
 	value := results.Value
 	for _, v := range results.Kept {
 		value = value.Add(value, big.NewInt(int64(v)))
 	}
 	results.Value = value
+	h.OnResult(results)
 
 	return results
 }
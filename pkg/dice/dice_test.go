@@ -52,12 +52,12 @@ func testDiceNew(t *testing.T) {
 	})
 	t.Run("InvalidNumber", func(t *testing.T) {
 		d, err := New(0, 20)
-		require.EqualError(t, err, ErrNumberTooLow(0).Error())
+		require.ErrorIs(t, err, ErrNumberTooLow)
 		require.Nil(t, d)
 	})
 	t.Run("InvalidSides", func(t *testing.T) {
 		d, err := New(2, 1)
-		require.EqualError(t, err, ErrSidesTooLow(1).Error())
+		require.ErrorIs(t, err, ErrSidesTooLow)
 		require.Nil(t, d)
 	})
 }
@@ -73,22 +73,22 @@ func testDiceNewExt(t *testing.T) {
 	})
 	t.Run("InvalidNumber", func(t *testing.T) {
 		d, err := NewExt(0, 20, 0, 0)
-		require.EqualError(t, err, ErrNumberTooLow(0).Error())
+		require.ErrorIs(t, err, ErrNumberTooLow)
 		require.Nil(t, d)
 	})
 	t.Run("InvalidSides", func(t *testing.T) {
 		d, err := NewExt(2, 1, 0, 0)
-		require.EqualError(t, err, ErrSidesTooLow(1).Error())
+		require.ErrorIs(t, err, ErrSidesTooLow)
 		require.Nil(t, d)
 	})
 	t.Run("InvalidDropLow", func(t *testing.T) {
 		d, err := NewExt(2, 20, -1, 0)
-		require.EqualError(t, err, ErrDropLowTooLow(-1).Error())
+		require.ErrorIs(t, err, ErrDropLowTooLow)
 		require.Nil(t, d)
 	})
 	t.Run("InvalidDropHigh", func(t *testing.T) {
 		d, err := NewExt(2, 20, 0, -1)
-		require.EqualError(t, err, ErrDropHighTooLow(-1).Error())
+		require.ErrorIs(t, err, ErrDropHighTooLow)
 		require.Nil(t, d)
 	})
 }
@@ -106,14 +106,14 @@ func testDiceValidate(t *testing.T) {
 			Number: 0,
 			Sides:  20,
 		}
-		require.EqualError(t, d.Validate(), ErrNumberTooLow(0).Error())
+		require.ErrorIs(t, d.Validate(), ErrNumberTooLow)
 	})
 	t.Run("InvalidSides", func(t *testing.T) {
 		d := &Dice{
 			Number: 2,
 			Sides:  1,
 		}
-		require.EqualError(t, d.Validate(), ErrSidesTooLow(1).Error())
+		require.ErrorIs(t, d.Validate(), ErrSidesTooLow)
 	})
 	t.Run("InvalidDropLow", func(t *testing.T) {
 		d := &Dice{
@@ -121,7 +121,7 @@ func testDiceValidate(t *testing.T) {
 			Sides:   20,
 			DropLow: -1,
 		}
-		require.EqualError(t, d.Validate(), ErrDropLowTooLow(-1).Error())
+		require.ErrorIs(t, d.Validate(), ErrDropLowTooLow)
 	})
 	t.Run("InvalidDropHigh", func(t *testing.T) {
 		d := &Dice{
@@ -129,7 +129,7 @@ func testDiceValidate(t *testing.T) {
 			Sides:    20,
 			DropHigh: -1,
 		}
-		require.EqualError(t, d.Validate(), ErrDropHighTooLow(-1).Error())
+		require.ErrorIs(t, d.Validate(), ErrDropHighTooLow)
 	})
 	t.Run("TooManyLowDropped", func(t *testing.T) {
 		d := &Dice{
@@ -137,7 +137,7 @@ func testDiceValidate(t *testing.T) {
 			Sides:   20,
 			DropLow: 2,
 		}
-		require.EqualError(t, d.Validate(), ErrTooManyDropped(2, 0, 2).Error())
+		require.ErrorIs(t, d.Validate(), ErrTooManyDropped)
 	})
 	t.Run("TooManyTotalDropped", func(t *testing.T) {
 		d := &Dice{
@@ -146,7 +146,7 @@ func testDiceValidate(t *testing.T) {
 			DropLow:  1,
 			DropHigh: 1,
 		}
-		require.EqualError(t, d.Validate(), ErrTooManyDropped(1, 1, 2).Error())
+		require.ErrorIs(t, d.Validate(), ErrTooManyDropped)
 	})
 }
 
@@ -0,0 +1,276 @@
+package dice
+
+import (
+	"math/big"
+)
+
+// MaxDistributionDice is the largest Number a Dice instance may have before
+// Distribution refuses to compute its exact probability distribution. Dice
+// which drop low or high results are evaluated with a dynamic-programming
+// pass whose cost grows with Number, so this cap exists as a backstop
+// against unreasonably large pools. Dice which drop nothing always use a
+// cheap convolution and are not subject to this cap.
+var MaxDistributionDice = 30
+
+// Distribution is the exact probability distribution of the sum produced by
+// rolling a particular Dice instance, computed with exact rational
+// arithmetic rather than simulation.
+type Distribution struct {
+	min, max int
+	counts   map[int]*big.Int
+	denom    *big.Int
+}
+
+// Distribution computes and caches the exact probability distribution of
+// this Dice instance's possible results. The computation is pure with
+// respect to (Number, Sides, DropLow, DropHigh), so it is only performed
+// once per Dice instance.
+//
+// If this Dice drops low or high results and its Number exceeds
+// MaxDistributionDice, an error is returned instead of enumerating the
+// distribution.
+func (d *Dice) Distribution() (*Distribution, error) {
+	d.distOnce.Do(func() {
+		d.dist, d.distErr = computeDistribution(d)
+	})
+	return d.dist, d.distErr
+}
+
+func computeDistribution(d *Dice) (*Distribution, error) {
+	denom := new(big.Int).Exp(big.NewInt(int64(d.Sides)), big.NewInt(int64(d.Number)), nil)
+
+	if d.DropLow == 0 && d.DropHigh == 0 {
+		return newDistribution(convolvePMF(d.Sides, d.Number), denom), nil
+	}
+
+	if d.Number > MaxDistributionDice {
+		return nil, &DistributionTooLargeError{N: d.Number, Max: MaxDistributionDice}
+	}
+
+	return newDistribution(enumerateDistribution(d), denom), nil
+}
+
+// convolvePMF computes the integer histogram of sums produced by rolling
+// `number` uniform dice with `sides` faces, by iterated convolution of the
+// single-die distribution. Every outcome is equally likely, so the
+// histogram need only track counts - the probability of each sum is its
+// count divided by sides^number.
+func convolvePMF(sides, number int) map[int]*big.Int {
+	current := make(map[int]*big.Int, sides)
+	for v := 1; v <= sides; v++ {
+		current[v] = big.NewInt(1)
+	}
+
+	for i := 1; i < number; i++ {
+		next := make(map[int]*big.Int)
+		for sum, count := range current {
+			for v := 1; v <= sides; v++ {
+				total := sum + v
+				c, ok := next[total]
+				if !ok {
+					c = new(big.Int)
+				}
+				next[total] = new(big.Int).Add(c, count)
+			}
+		}
+		current = next
+	}
+
+	return current
+}
+
+// distState is a DP state used by enumerateDistribution: the number of
+// dice whose face value has been decided so far (in ascending order), and
+// the running sum contributed by whichever of those dice fell within the
+// kept window.
+type distState struct {
+	placed int
+	sum    int
+}
+
+// enumerateDistribution computes the integer histogram of kept sums for a
+// Dice which drops low and/or high results.
+//
+// Conceptually, sort the N dice ascending and assign face values in
+// non-decreasing order, one face value at a time from 1 to Sides. Deciding
+// that k of the remaining (N - placed) dice take the current face value
+// fixes those dice into sorted positions [placed+1, placed+k] - which of
+// those positions fall inside the kept window [DropLow+1, N-DropHigh] is
+// known immediately, so the contribution of this step to the kept sum can
+// be added without knowing the rest of the assignment. The number of ways
+// to choose which k of the remaining dice take this face is C(N-placed, k);
+// multiplying these binomial coefficients together across every face value
+// reconstructs the same N!/(c_1!...c_Sides!) multinomial coefficient that
+// enumerating every sorted tuple would compute directly, but in time
+// polynomial in N and Sides instead of combinatorial in both.
+func enumerateDistribution(d *Dice) map[int]*big.Int {
+	binom := binomialTable(d.Number)
+
+	dp := map[distState]*big.Int{{placed: 0, sum: 0}: big.NewInt(1)}
+	keptLow, keptHigh := d.DropLow+1, d.Number-d.DropHigh
+
+	for v := 1; v <= d.Sides; v++ {
+		next := make(map[distState]*big.Int, len(dp))
+		for st, count := range dp {
+			remaining := d.Number - st.placed
+			for k := 0; k <= remaining; k++ {
+				weight := binom[remaining][k]
+				placed := st.placed + k
+
+				overlapStart := st.placed + 1
+				if overlapStart < keptLow {
+					overlapStart = keptLow
+				}
+				overlapEnd := placed
+				if overlapEnd > keptHigh {
+					overlapEnd = keptHigh
+				}
+				overlap := overlapEnd - overlapStart + 1
+				if overlap < 0 {
+					overlap = 0
+				}
+
+				ns := distState{placed: placed, sum: st.sum + overlap*v}
+				ways := new(big.Int).Mul(count, weight)
+				if existing, ok := next[ns]; ok {
+					ways.Add(ways, existing)
+				}
+				next[ns] = ways
+			}
+		}
+		dp = next
+	}
+
+	counts := make(map[int]*big.Int, len(dp))
+	for st, count := range dp {
+		if st.placed != d.Number {
+			// Every die must take some face in [1, Sides], so any state
+			// that hasn't placed all N dice by the time every face value
+			// has been considered is the spurious "decline every face"
+			// path through the DP - k=0 is always a valid choice, so it
+			// survives every step even though it isn't a real outcome.
+			continue
+		}
+		c, ok := counts[st.sum]
+		if !ok {
+			c = new(big.Int)
+		}
+		counts[st.sum] = new(big.Int).Add(c, count)
+	}
+	return counts
+}
+
+// binomialTable returns Pascal's triangle up to row n, so that
+// binomialTable(n)[a][b] is C(a, b) for 0 <= b <= a <= n.
+func binomialTable(n int) [][]*big.Int {
+	table := make([][]*big.Int, n+1)
+	for i := 0; i <= n; i++ {
+		row := make([]*big.Int, i+1)
+		row[0] = big.NewInt(1)
+		row[i] = big.NewInt(1)
+		for j := 1; j < i; j++ {
+			row[j] = new(big.Int).Add(table[i-1][j-1], table[i-1][j])
+		}
+		table[i] = row
+	}
+	return table
+}
+
+func newDistribution(counts map[int]*big.Int, denom *big.Int) *Distribution {
+	min, max := 0, 0
+	first := true
+	for v := range counts {
+		if first {
+			min, max = v, v
+			first = false
+			continue
+		}
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	return &Distribution{min: min, max: max, counts: counts, denom: denom}
+}
+
+// PMF returns the probability mass function of this Distribution, mapping
+// each possible result to its exact probability.
+func (dist *Distribution) PMF() map[int]*big.Rat {
+	out := make(map[int]*big.Rat, len(dist.counts))
+	for v, c := range dist.counts {
+		out[v] = new(big.Rat).SetFrac(c, dist.denom)
+	}
+	return out
+}
+
+// CDF returns the cumulative distribution function of this Distribution as
+// a slice indexed from 0, where CDF()[i] is the probability of rolling at
+// most Min()+i.
+func (dist *Distribution) CDF() []*big.Rat {
+	out := make([]*big.Rat, dist.max-dist.min+1)
+	running := new(big.Int)
+	for i := range out {
+		v := dist.min + i
+		if c, ok := dist.counts[v]; ok {
+			running = new(big.Int).Add(running, c)
+		}
+		out[i] = new(big.Rat).SetFrac(new(big.Int).Set(running), dist.denom)
+	}
+	return out
+}
+
+// Mean returns the exact expected value of this Distribution.
+func (dist *Distribution) Mean() *big.Rat {
+	sum := new(big.Int)
+	for v, c := range dist.counts {
+		sum.Add(sum, new(big.Int).Mul(big.NewInt(int64(v)), c))
+	}
+	return new(big.Rat).SetFrac(sum, dist.denom)
+}
+
+// Variance returns the exact variance of this Distribution, computed as
+// E[X^2] - E[X]^2.
+func (dist *Distribution) Variance() *big.Rat {
+	mean := dist.Mean()
+
+	sumSq := new(big.Int)
+	for v, c := range dist.counts {
+		vv := big.NewInt(int64(v) * int64(v))
+		sumSq.Add(sumSq, new(big.Int).Mul(vv, c))
+	}
+	meanOfSquares := new(big.Rat).SetFrac(sumSq, dist.denom)
+
+	return new(big.Rat).Sub(meanOfSquares, new(big.Rat).Mul(mean, mean))
+}
+
+// Min returns the smallest possible result of this Distribution.
+func (dist *Distribution) Min() int {
+	return dist.min
+}
+
+// Max returns the largest possible result of this Distribution.
+func (dist *Distribution) Max() int {
+	return dist.max
+}
+
+// ProbAtLeast returns the exact probability of rolling a result greater
+// than or equal to v.
+func (dist *Distribution) ProbAtLeast(v int) *big.Rat {
+	if v <= dist.min {
+		return big.NewRat(1, 1)
+	}
+	if v > dist.max {
+		return big.NewRat(0, 1)
+	}
+
+	sum := new(big.Int)
+	for value, c := range dist.counts {
+		if value >= v {
+			sum.Add(sum, c)
+		}
+	}
+	return new(big.Rat).SetFrac(sum, dist.denom)
+}
@@ -0,0 +1,97 @@
+package dice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistribution(t *testing.T) {
+	t.Run("SingleDie", testDistributionSingleDie)
+	t.Run("TwoDiceSum", testDistributionTwoDiceSum)
+	t.Run("DropLow", testDistributionDropLow)
+	t.Run("Cached", testDistributionCached)
+	t.Run("TooLarge", testDistributionTooLarge)
+}
+
+func testDistributionSingleDie(t *testing.T) {
+	d, err := New(1, 6)
+	require.NoError(t, err)
+
+	dist, err := d.Distribution()
+	require.NoError(t, err)
+	require.Equal(t, 1, dist.Min())
+	require.Equal(t, 6, dist.Max())
+	require.Equal(t, big.NewRat(7, 2), dist.Mean())
+
+	pmf := dist.PMF()
+	require.Len(t, pmf, 6)
+	for v := 1; v <= 6; v++ {
+		require.Equal(t, big.NewRat(1, 6), pmf[v])
+	}
+
+	require.Equal(t, big.NewRat(1, 2), dist.ProbAtLeast(4))
+	require.Equal(t, big.NewRat(1, 1), dist.ProbAtLeast(1))
+	require.Equal(t, big.NewRat(0, 1), dist.ProbAtLeast(7))
+}
+
+func testDistributionTwoDiceSum(t *testing.T) {
+	d, err := New(2, 6)
+	require.NoError(t, err)
+
+	dist, err := d.Distribution()
+	require.NoError(t, err)
+	require.Equal(t, 2, dist.Min())
+	require.Equal(t, 12, dist.Max())
+
+	pmf := dist.PMF()
+	require.Equal(t, big.NewRat(1, 36), pmf[2])
+	require.Equal(t, big.NewRat(6, 36), pmf[7])
+	require.Equal(t, big.NewRat(1, 36), pmf[12])
+
+	cdf := dist.CDF()
+	require.Equal(t, big.NewRat(1, 36), cdf[0])
+	require.Equal(t, big.NewRat(1, 1), cdf[len(cdf)-1])
+}
+
+func testDistributionDropLow(t *testing.T) {
+	// 2d6, dropping the lowest, keeps the max of the two dice. The chance
+	// of the max being exactly k is (2k-1)/36 for k in [1, 6].
+	d, err := NewExt(2, 6, 1, 0)
+	require.NoError(t, err)
+
+	dist, err := d.Distribution()
+	require.NoError(t, err)
+	require.Equal(t, 1, dist.Min())
+	require.Equal(t, 6, dist.Max())
+
+	pmf := dist.PMF()
+	for k := 1; k <= 6; k++ {
+		require.Equal(t, big.NewRat(int64(2*k-1), 36), pmf[k])
+	}
+}
+
+func testDistributionCached(t *testing.T) {
+	d, err := New(2, 20)
+	require.NoError(t, err)
+
+	first, err := d.Distribution()
+	require.NoError(t, err)
+	second, err := d.Distribution()
+	require.NoError(t, err)
+	require.Same(t, first, second)
+}
+
+func testDistributionTooLarge(t *testing.T) {
+	old := MaxDistributionDice
+	MaxDistributionDice = 2
+	defer func() { MaxDistributionDice = old }()
+
+	d, err := NewExt(3, 6, 1, 0)
+	require.NoError(t, err)
+
+	dist, err := d.Distribution()
+	require.Nil(t, dist)
+	require.ErrorIs(t, err, ErrDistributionTooLarge)
+}
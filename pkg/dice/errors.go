@@ -4,32 +4,343 @@ import (
 	"fmt"
 )
 
-// ErrNumberTooLow returns an error indicating that the number of dice to roll
-// is too low.
-func ErrNumberTooLow(num int) error {
-	return fmt.Errorf("number of dice is too low: %d", num)
+// This file defines the module's canonical error convention: each failure
+// mode is a named struct type carrying the data needed to describe it, with
+// an `Error() string` and an `Is(target error) bool` method, plus an
+// exported sentinel value of that type with its zero value. Callers should
+// use `errors.Is(err, dice.ErrNumberTooLow)` rather than comparing error
+// strings, while string-based consumers keep working unchanged since the
+// message formats are preserved.
+
+// NumberTooLowError indicates that the number of dice to roll is too low.
+type NumberTooLowError struct {
+	N int
+}
+
+// Error returns a message describing this error.
+func (e *NumberTooLowError) Error() string {
+	return fmt.Sprintf("number of dice is too low: %d", e.N)
+}
+
+// Is reports whether target is a NumberTooLowError, so that this error
+// matches the ErrNumberTooLow sentinel via errors.Is.
+func (e *NumberTooLowError) Is(target error) bool {
+	_, ok := target.(*NumberTooLowError)
+	return ok
+}
+
+// ErrNumberTooLow is the sentinel value matched by errors.Is for any
+// NumberTooLowError.
+var ErrNumberTooLow error = &NumberTooLowError{}
+
+// SidesTooLowError indicates that the number of sides on the dice is too
+// low.
+type SidesTooLowError struct {
+	N int
+}
+
+// Error returns a message describing this error.
+func (e *SidesTooLowError) Error() string {
+	return fmt.Sprintf("number of sides is too low: %d", e.N)
+}
+
+// Is reports whether target is a SidesTooLowError, so that this error
+// matches the ErrSidesTooLow sentinel via errors.Is.
+func (e *SidesTooLowError) Is(target error) bool {
+	_, ok := target.(*SidesTooLowError)
+	return ok
 }
 
-// ErrSidesTooLow returns an error indicating that the number of sides on the
-// dice is too low.
-func ErrSidesTooLow(num int) error {
-	return fmt.Errorf("number of sides is too low: %d", num)
+// ErrSidesTooLow is the sentinel value matched by errors.Is for any
+// SidesTooLowError.
+var ErrSidesTooLow error = &SidesTooLowError{}
+
+// DropLowTooLowError indicates that the number of low dice to drop is less
+// than zero.
+type DropLowTooLowError struct {
+	N int
+}
+
+// Error returns a message describing this error.
+func (e *DropLowTooLowError) Error() string {
+	return fmt.Sprintf("number of low dice to drop must be positive: %d", e.N)
+}
+
+// Is reports whether target is a DropLowTooLowError, so that this error
+// matches the ErrDropLowTooLow sentinel via errors.Is.
+func (e *DropLowTooLowError) Is(target error) bool {
+	_, ok := target.(*DropLowTooLowError)
+	return ok
+}
+
+// ErrDropLowTooLow is the sentinel value matched by errors.Is for any
+// DropLowTooLowError.
+var ErrDropLowTooLow error = &DropLowTooLowError{}
+
+// DropHighTooLowError indicates that the number of high dice to drop is
+// less than zero.
+type DropHighTooLowError struct {
+	N int
+}
+
+// Error returns a message describing this error.
+func (e *DropHighTooLowError) Error() string {
+	return fmt.Sprintf("number of high dice to drop must be positive: %d", e.N)
 }
 
-// ErrDropLowTooLow returns an error indicating that the number of low dice
-// to drop is less than zero.
-func ErrDropLowTooLow(num int) error {
-	return fmt.Errorf("number of low dice to drop must be positive: %d", num)
+// Is reports whether target is a DropHighTooLowError, so that this error
+// matches the ErrDropHighTooLow sentinel via errors.Is.
+func (e *DropHighTooLowError) Is(target error) bool {
+	_, ok := target.(*DropHighTooLowError)
+	return ok
 }
 
-// ErrDropHighTooLow returns an error indicating that the number of high dice
-// to drop is less than zero.
-func ErrDropHighTooLow(num int) error {
-	return fmt.Errorf("number of high dice to drop must be positive: %d", num)
+// ErrDropHighTooLow is the sentinel value matched by errors.Is for any
+// DropHighTooLowError.
+var ErrDropHighTooLow error = &DropHighTooLowError{}
+
+// TooManyDroppedError indicates that too many dice in a dice specification
+// would be dropped to return a result.
+type TooManyDroppedError struct {
+	Low    int
+	High   int
+	Number int
 }
 
-// ErrTooManyDropped returns an error indicating that too many dice in a dice
-// specification would be dropped to return a result.
-func ErrTooManyDropped(low, high, num int) error {
-	return fmt.Errorf("too many dice dropped: %d + %d >= %d", low, high, num)
+// Error returns a message describing this error.
+func (e *TooManyDroppedError) Error() string {
+	return fmt.Sprintf("too many dice dropped: %d + %d >= %d", e.Low, e.High, e.Number)
+}
+
+// Is reports whether target is a TooManyDroppedError, so that this error
+// matches the ErrTooManyDropped sentinel via errors.Is.
+func (e *TooManyDroppedError) Is(target error) bool {
+	_, ok := target.(*TooManyDroppedError)
+	return ok
+}
+
+// ErrTooManyDropped is the sentinel value matched by errors.Is for any
+// TooManyDroppedError.
+var ErrTooManyDropped error = &TooManyDroppedError{}
+
+// UnexpectedCharacterError indicates that an expression string contains a
+// character which isn't valid anywhere in the grammar.
+type UnexpectedCharacterError struct {
+	C rune
+}
+
+// Error returns a message describing this error.
+func (e *UnexpectedCharacterError) Error() string {
+	return fmt.Sprintf("unexpected character: %q", e.C)
+}
+
+// Is reports whether target is an UnexpectedCharacterError, so that this
+// error matches the ErrUnexpectedCharacter sentinel via errors.Is.
+func (e *UnexpectedCharacterError) Is(target error) bool {
+	_, ok := target.(*UnexpectedCharacterError)
+	return ok
+}
+
+// ErrUnexpectedCharacter is the sentinel value matched by errors.Is for any
+// UnexpectedCharacterError.
+var ErrUnexpectedCharacter error = &UnexpectedCharacterError{}
+
+// ExpectedSidesError indicates that a dice specification is missing the
+// number of sides after its 'd'.
+type ExpectedSidesError struct{}
+
+// Error returns a message describing this error.
+func (e *ExpectedSidesError) Error() string {
+	return "expected number of sides after 'd'"
+}
+
+// Is reports whether target is an ExpectedSidesError, so that this error
+// matches the ErrExpectedSides sentinel via errors.Is.
+func (e *ExpectedSidesError) Is(target error) bool {
+	_, ok := target.(*ExpectedSidesError)
+	return ok
+}
+
+// ErrExpectedSides is the sentinel value matched by errors.Is for any
+// ExpectedSidesError.
+var ErrExpectedSides error = &ExpectedSidesError{}
+
+// ExpectedDropCountError indicates that a dice specification is missing the
+// count after an 'L' or 'H' modifier.
+type ExpectedDropCountError struct{}
+
+// Error returns a message describing this error.
+func (e *ExpectedDropCountError) Error() string {
+	return "expected a count after 'L' or 'H'"
+}
+
+// Is reports whether target is an ExpectedDropCountError, so that this
+// error matches the ErrExpectedDropCount sentinel via errors.Is.
+func (e *ExpectedDropCountError) Is(target error) bool {
+	_, ok := target.(*ExpectedDropCountError)
+	return ok
+}
+
+// ErrExpectedDropCount is the sentinel value matched by errors.Is for any
+// ExpectedDropCountError.
+var ErrExpectedDropCount error = &ExpectedDropCountError{}
+
+// InvalidDiceSpecError indicates that the numbers in a dice specification
+// could not be parsed as integers. A specification whose numbers parse but
+// describe an invalid Dice instance is reported with the error from
+// Validate instead (e.g. ErrSidesTooLow).
+type InvalidDiceSpecError struct {
+	Spec string
 }
+
+// Error returns a message describing this error.
+func (e *InvalidDiceSpecError) Error() string {
+	return fmt.Sprintf("invalid dice specification: %q", e.Spec)
+}
+
+// Is reports whether target is an InvalidDiceSpecError, so that this error
+// matches the ErrInvalidDiceSpec sentinel via errors.Is.
+func (e *InvalidDiceSpecError) Is(target error) bool {
+	_, ok := target.(*InvalidDiceSpecError)
+	return ok
+}
+
+// ErrInvalidDiceSpec is the sentinel value matched by errors.Is for any
+// InvalidDiceSpecError.
+var ErrInvalidDiceSpec error = &InvalidDiceSpecError{}
+
+// InvalidNumberError indicates that a numeric token could not be parsed as
+// an integer.
+type InvalidNumberError struct {
+	S string
+}
+
+// Error returns a message describing this error.
+func (e *InvalidNumberError) Error() string {
+	return fmt.Sprintf("invalid number: %q", e.S)
+}
+
+// Is reports whether target is an InvalidNumberError, so that this error
+// matches the ErrInvalidNumber sentinel via errors.Is.
+func (e *InvalidNumberError) Is(target error) bool {
+	_, ok := target.(*InvalidNumberError)
+	return ok
+}
+
+// ErrInvalidNumber is the sentinel value matched by errors.Is for any
+// InvalidNumberError.
+var ErrInvalidNumber error = &InvalidNumberError{}
+
+// UnexpectedTokenError indicates that an expression contains a token which
+// isn't valid at that point in the grammar.
+type UnexpectedTokenError struct {
+	Tok string
+}
+
+// Error returns a message describing this error.
+func (e *UnexpectedTokenError) Error() string {
+	if e.Tok == "" {
+		return "unexpected end of expression"
+	}
+	return fmt.Sprintf("unexpected token: %q", e.Tok)
+}
+
+// Is reports whether target is an UnexpectedTokenError, so that this error
+// matches the ErrUnexpectedToken sentinel via errors.Is.
+func (e *UnexpectedTokenError) Is(target error) bool {
+	_, ok := target.(*UnexpectedTokenError)
+	return ok
+}
+
+// ErrUnexpectedToken is the sentinel value matched by errors.Is for any
+// UnexpectedTokenError.
+var ErrUnexpectedToken error = &UnexpectedTokenError{}
+
+// ExpectedTokenError indicates that the parser expected a specific token
+// but did not find one.
+type ExpectedTokenError struct {
+	Tok string
+}
+
+// Error returns a message describing this error.
+func (e *ExpectedTokenError) Error() string {
+	return fmt.Sprintf("expected %q", e.Tok)
+}
+
+// Is reports whether target is an ExpectedTokenError, so that this error
+// matches the ErrExpectedToken sentinel via errors.Is.
+func (e *ExpectedTokenError) Is(target error) bool {
+	_, ok := target.(*ExpectedTokenError)
+	return ok
+}
+
+// ErrExpectedToken is the sentinel value matched by errors.Is for any
+// ExpectedTokenError.
+var ErrExpectedToken error = &ExpectedTokenError{}
+
+// DistributionTooLargeError indicates that a Dice instance drops low or
+// high results and has more dice than MaxDistributionDice allows, so its
+// exact Distribution was not enumerated.
+type DistributionTooLargeError struct {
+	N   int
+	Max int
+}
+
+// Error returns a message describing this error.
+func (e *DistributionTooLargeError) Error() string {
+	return fmt.Sprintf("distribution would enumerate too many dice: %d > %d", e.N, e.Max)
+}
+
+// Is reports whether target is a DistributionTooLargeError, so that this
+// error matches the ErrDistributionTooLarge sentinel via errors.Is.
+func (e *DistributionTooLargeError) Is(target error) bool {
+	_, ok := target.(*DistributionTooLargeError)
+	return ok
+}
+
+// ErrDistributionTooLarge is the sentinel value matched by errors.Is for
+// any DistributionTooLargeError.
+var ErrDistributionTooLarge error = &DistributionTooLargeError{}
+
+// UnknownFunctionError indicates that an expression calls a function name
+// which isn't one of the parser's known functions (currently "min" and
+// "max").
+type UnknownFunctionError struct {
+	Name string
+}
+
+// Error returns a message describing this error.
+func (e *UnknownFunctionError) Error() string {
+	return fmt.Sprintf("unknown function: %q", e.Name)
+}
+
+// Is reports whether target is an UnknownFunctionError, so that this error
+// matches the ErrUnknownFunction sentinel via errors.Is.
+func (e *UnknownFunctionError) Is(target error) bool {
+	_, ok := target.(*UnknownFunctionError)
+	return ok
+}
+
+// ErrUnknownFunction is the sentinel value matched by errors.Is for any
+// UnknownFunctionError.
+var ErrUnknownFunction error = &UnknownFunctionError{}
+
+// DivisionByZeroError indicates that an expression divides by a literal
+// zero, which would panic when rolled.
+type DivisionByZeroError struct{}
+
+// Error returns a message describing this error.
+func (e *DivisionByZeroError) Error() string {
+	return "division by zero"
+}
+
+// Is reports whether target is a DivisionByZeroError, so that this error
+// matches the ErrDivisionByZero sentinel via errors.Is.
+func (e *DivisionByZeroError) Is(target error) bool {
+	_, ok := target.(*DivisionByZeroError)
+	return ok
+}
+
+// ErrDivisionByZero is the sentinel value matched by errors.Is for any
+// DivisionByZeroError.
+var ErrDivisionByZero error = &DivisionByZeroError{}
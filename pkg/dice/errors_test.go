@@ -0,0 +1,30 @@
+package dice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrors(t *testing.T) {
+	t.Run("MessagesPreserved", testErrorMessages)
+	t.Run("ErrorsIs", testErrorsIs)
+}
+
+func testErrorMessages(t *testing.T) {
+	require.Equal(t, "number of dice is too low: 0", (&NumberTooLowError{N: 0}).Error())
+	require.Equal(t, "number of sides is too low: 1", (&SidesTooLowError{N: 1}).Error())
+	require.Equal(t, "number of low dice to drop must be positive: -1", (&DropLowTooLowError{N: -1}).Error())
+	require.Equal(t, "number of high dice to drop must be positive: -1", (&DropHighTooLowError{N: -1}).Error())
+	require.Equal(t, "too many dice dropped: 2 + 0 >= 2", (&TooManyDroppedError{Low: 2, High: 0, Number: 2}).Error())
+}
+
+func testErrorsIs(t *testing.T) {
+	err := error(&NumberTooLowError{N: 3})
+	require.True(t, errors.Is(err, ErrNumberTooLow))
+	require.False(t, errors.Is(err, ErrSidesTooLow))
+
+	_, parseErr := Parse("2d")
+	require.ErrorIs(t, parseErr, ErrExpectedSides)
+}
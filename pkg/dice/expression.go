@@ -0,0 +1,294 @@
+package dice
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Expression is a node in a dice expression AST. It represents anything
+// which can be rolled to produce a single numeric result - a constant, a
+// single Dice specification, a binary operation combining two
+// sub-expressions, or a function call over one or more sub-expressions.
+type Expression interface {
+	// String returns a string representation of this Expression which, when
+	// passed to Parse, produces an equivalent Expression.
+	String() string
+
+	// Roll evaluates this Expression using the given Rand implementation and
+	// returns the resulting value.
+	Roll(r Rand) *big.Int
+
+	// RollResults evaluates this Expression using the given Rand
+	// implementation and returns the resulting value along with the
+	// individual Results of every DiceNode encountered during evaluation.
+	RollResults(r Rand) *ExpressionResults
+}
+
+// ExpressionResults is the set of results from rolling an Expression.
+type ExpressionResults struct {
+	Value *big.Int
+	Dice  []*Results
+}
+
+// ConstNode is an Expression which always evaluates to a fixed value.
+type ConstNode struct {
+	Value *big.Int
+}
+
+// NewConstNode creates a new ConstNode with the given integer value.
+func NewConstNode(value int64) *ConstNode {
+	return &ConstNode{Value: big.NewInt(value)}
+}
+
+// String returns a string representation of this ConstNode.
+func (n *ConstNode) String() string {
+	return n.Value.String()
+}
+
+// Roll returns the constant value of this ConstNode. The given Rand is
+// never used.
+func (n *ConstNode) Roll(r Rand) *big.Int {
+	return new(big.Int).Set(n.Value)
+}
+
+// RollResults returns the constant value of this ConstNode wrapped in an
+// ExpressionResults with no dice rolls.
+func (n *ConstNode) RollResults(r Rand) *ExpressionResults {
+	return &ExpressionResults{Value: n.Roll(r)}
+}
+
+// DiceNode is an Expression which wraps a Dice specification.
+type DiceNode struct {
+	Dice *Dice
+}
+
+// NewDiceNode creates a new DiceNode wrapping the given Dice instance.
+func NewDiceNode(d *Dice) *DiceNode {
+	return &DiceNode{Dice: d}
+}
+
+// String returns a string representation of this DiceNode.
+func (n *DiceNode) String() string {
+	return n.Dice.String()
+}
+
+// Roll rolls the wrapped Dice instance using the given Rand implementation.
+func (n *DiceNode) Roll(r Rand) *big.Int {
+	return n.Dice.RollRand(r)
+}
+
+// RollResults rolls the wrapped Dice instance using the given Rand
+// implementation and returns the detailed Results alongside the value.
+func (n *DiceNode) RollResults(r Rand) *ExpressionResults {
+	results := n.Dice.RollResults(r)
+	return &ExpressionResults{
+		Value: results.Value,
+		Dice:  []*Results{results},
+	}
+}
+
+// BinOp identifies the operator used by a BinOpNode.
+type BinOp byte
+
+// The set of binary operators supported by a BinOpNode.
+const (
+	OpAdd BinOp = '+'
+	OpSub BinOp = '-'
+	OpMul BinOp = '*'
+	OpDiv BinOp = '/'
+)
+
+// String returns the textual representation of this BinOp.
+func (op BinOp) String() string {
+	return string(rune(op))
+}
+
+// precedence returns this BinOp's binding strength relative to the other
+// BinOps, matching the grammar's parseExpr/parseTerm split: "+" and "-" bind
+// loosest, "*" and "/" bind tighter.
+func (op BinOp) precedence() int {
+	switch op {
+	case OpAdd, OpSub:
+		return 1
+	case OpMul, OpDiv:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// BinOpNode is an Expression which combines two sub-expressions with a
+// binary operator.
+type BinOpNode struct {
+	Op    BinOp
+	Left  Expression
+	Right Expression
+}
+
+// NewBinOpNode creates a new BinOpNode combining the left and right
+// Expression with the given operator.
+func NewBinOpNode(op BinOp, left, right Expression) *BinOpNode {
+	return &BinOpNode{Op: op, Left: left, Right: right}
+}
+
+// String returns a string representation of this BinOpNode. Parentheses are
+// added around a child expression whenever omitting them would change how it
+// reparses - either because the child binds looser than this node, or
+// because it sits on the right at the same precedence, since Parse groups
+// equal-precedence operators to the left - so that String() round-trips
+// through Parse.
+func (n *BinOpNode) String() string {
+	left := n.Left.String()
+	if binOpNeedsParens(n.Op, n.Left, false) {
+		left = "(" + left + ")"
+	}
+	right := n.Right.String()
+	if binOpNeedsParens(n.Op, n.Right, true) {
+		right = "(" + right + ")"
+	}
+	return fmt.Sprintf("%s %s %s", left, n.Op.String(), right)
+}
+
+// binOpNeedsParens reports whether child, appearing as the left or right
+// operand of a BinOpNode using parentOp, must be parenthesized to preserve
+// its grouping when reparsed.
+func binOpNeedsParens(parentOp BinOp, child Expression, isRight bool) bool {
+	childNode, ok := child.(*BinOpNode)
+	if !ok {
+		return false
+	}
+	parentPrec, childPrec := parentOp.precedence(), childNode.Op.precedence()
+	if childPrec < parentPrec {
+		return true
+	}
+	return childPrec == parentPrec && isRight
+}
+
+// Roll evaluates both sub-expressions with the given Rand implementation and
+// combines them with this node's operator.
+func (n *BinOpNode) Roll(r Rand) *big.Int {
+	left := n.Left.Roll(r)
+	right := n.Right.Roll(r)
+	return applyBinOp(n.Op, left, right)
+}
+
+// RollResults evaluates both sub-expressions with the given Rand
+// implementation, combines the resulting values with this node's operator,
+// and merges the DiceNode results from both sides.
+func (n *BinOpNode) RollResults(r Rand) *ExpressionResults {
+	left := n.Left.RollResults(r)
+	right := n.Right.RollResults(r)
+	return &ExpressionResults{
+		Value: applyBinOp(n.Op, left.Value, right.Value),
+		Dice:  append(left.Dice, right.Dice...),
+	}
+}
+
+func applyBinOp(op BinOp, left, right *big.Int) *big.Int {
+	result := new(big.Int)
+	switch op {
+	case OpAdd:
+		return result.Add(left, right)
+	case OpSub:
+		return result.Sub(left, right)
+	case OpMul:
+		return result.Mul(left, right)
+	case OpDiv:
+		return result.Quo(left, right)
+	default:
+		panic(fmt.Sprintf("dice: unknown binary operator %q", byte(op)))
+	}
+}
+
+// FuncNode is an Expression which applies a named function to one or more
+// sub-expressions. The currently supported functions are "min" and "max",
+// each of which accept one or more arguments.
+type FuncNode struct {
+	Name string
+	Args []Expression
+}
+
+// NewFuncNode creates a new FuncNode calling the named function with the
+// given argument expressions.
+func NewFuncNode(name string, args ...Expression) *FuncNode {
+	return &FuncNode{Name: name, Args: args}
+}
+
+// String returns a string representation of this FuncNode.
+func (n *FuncNode) String() string {
+	s := n.Name + "("
+	for i, arg := range n.Args {
+		if i > 0 {
+			s += ", "
+		}
+		s += arg.String()
+	}
+	return s + ")"
+}
+
+// Roll evaluates every argument with the given Rand implementation and
+// applies this node's function to the results.
+func (n *FuncNode) Roll(r Rand) *big.Int {
+	values := make([]*big.Int, len(n.Args))
+	for i, arg := range n.Args {
+		values[i] = arg.Roll(r)
+	}
+	return applyFunc(n.Name, values)
+}
+
+// RollResults evaluates every argument with the given Rand implementation,
+// applies this node's function to the results, and merges the DiceNode
+// results from every argument.
+func (n *FuncNode) RollResults(r Rand) *ExpressionResults {
+	values := make([]*big.Int, len(n.Args))
+	var dice []*Results
+	for i, arg := range n.Args {
+		results := arg.RollResults(r)
+		values[i] = results.Value
+		dice = append(dice, results.Dice...)
+	}
+	return &ExpressionResults{
+		Value: applyFunc(n.Name, values),
+		Dice:  dice,
+	}
+}
+
+// knownFunctions is the set of function names a FuncNode may carry. Parse
+// validates a parsed function call's name against this set so that a typo
+// like "mx(1d20, 1d20)" is rejected as a parse error rather than surfacing
+// as a panic the first time the resulting Expression is rolled.
+var knownFunctions = map[string]bool{
+	"min": true,
+	"max": true,
+}
+
+func applyFunc(name string, values []*big.Int) *big.Int {
+	switch name {
+	case "min":
+		return minBigInt(values)
+	case "max":
+		return maxBigInt(values)
+	default:
+		panic(fmt.Sprintf("dice: unknown function %q", name))
+	}
+}
+
+func minBigInt(values []*big.Int) *big.Int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v.Cmp(result) < 0 {
+			result = v
+		}
+	}
+	return new(big.Int).Set(result)
+}
+
+func maxBigInt(values []*big.Int) *big.Int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v.Cmp(result) > 0 {
+			result = v
+		}
+	}
+	return new(big.Int).Set(result)
+}
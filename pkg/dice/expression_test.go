@@ -0,0 +1,91 @@
+package dice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression(t *testing.T) {
+	t.Run("ConstNode", testConstNode)
+	t.Run("DiceNode", testDiceNode)
+	t.Run("BinOpNode", testBinOpNode)
+	t.Run("FuncNode", testFuncNode)
+}
+
+func testConstNode(t *testing.T) {
+	n := NewConstNode(7)
+	require.Equal(t, "7", n.String())
+	require.Equal(t, big.NewInt(7), n.Roll(mockMaxRand{}))
+
+	results := n.RollResults(mockMaxRand{})
+	require.Equal(t, big.NewInt(7), results.Value)
+	require.Nil(t, results.Dice)
+}
+
+func testDiceNode(t *testing.T) {
+	d, err := New(2, 20)
+	require.NoError(t, err)
+	n := NewDiceNode(d)
+	require.Equal(t, "2d20", n.String())
+	require.Equal(t, big.NewInt(40), n.Roll(mockMaxRand{}))
+
+	results := n.RollResults(mockMaxRand{})
+	require.Equal(t, big.NewInt(40), results.Value)
+	require.Len(t, results.Dice, 1)
+	require.Equal(t, []int{20, 20}, results.Dice[0].Raw)
+}
+
+func testBinOpNode(t *testing.T) {
+	d, err := New(1, 20)
+	require.NoError(t, err)
+
+	n := NewBinOpNode(OpAdd, NewDiceNode(d), NewConstNode(3))
+	require.Equal(t, "1d20 + 3", n.String())
+	require.Equal(t, big.NewInt(23), n.Roll(mockMaxRand{}))
+
+	results := n.RollResults(mockMaxRand{})
+	require.Equal(t, big.NewInt(23), results.Value)
+	require.Len(t, results.Dice, 1)
+
+	sub := NewBinOpNode(OpSub, NewConstNode(10), NewConstNode(4))
+	require.Equal(t, big.NewInt(6), sub.Roll(mockMaxRand{}))
+
+	mul := NewBinOpNode(OpMul, NewConstNode(4), NewConstNode(3))
+	require.Equal(t, big.NewInt(12), mul.Roll(mockMaxRand{}))
+
+	div := NewBinOpNode(OpDiv, NewConstNode(12), NewConstNode(4))
+	require.Equal(t, big.NewInt(3), div.Roll(mockMaxRand{}))
+
+	// A looser-binding child must be parenthesized so String() round-trips
+	// through Parse with its original grouping intact.
+	grouped := NewBinOpNode(OpMul, NewBinOpNode(OpAdd, NewConstNode(1), NewConstNode(2)), NewConstNode(3))
+	require.Equal(t, "(1 + 2) * 3", grouped.String())
+
+	// The right side of a non-associative operator needs parens even at
+	// equal precedence, since "10 - (4 - 1)" and "10 - 4 - 1" differ.
+	nonAssoc := NewBinOpNode(OpSub, NewConstNode(10), NewBinOpNode(OpSub, NewConstNode(4), NewConstNode(1)))
+	require.Equal(t, "10 - (4 - 1)", nonAssoc.String())
+
+	// Left-associative chains at equal precedence don't need parens, since
+	// "1 + 2 + 3" already reparses with the same left-leaning grouping.
+	chain := NewBinOpNode(OpAdd, NewBinOpNode(OpAdd, NewConstNode(1), NewConstNode(2)), NewConstNode(3))
+	require.Equal(t, "1 + 2 + 3", chain.String())
+}
+
+func testFuncNode(t *testing.T) {
+	n := NewFuncNode("max", NewConstNode(3), NewConstNode(9), NewConstNode(5))
+	require.Equal(t, "max(3, 9, 5)", n.String())
+	require.Equal(t, big.NewInt(9), n.Roll(mockMaxRand{}))
+
+	m := NewFuncNode("min", NewConstNode(3), NewConstNode(9), NewConstNode(5))
+	require.Equal(t, big.NewInt(3), m.Roll(mockMaxRand{}))
+
+	d, err := New(1, 20)
+	require.NoError(t, err)
+	withDice := NewFuncNode("max", NewDiceNode(d), NewConstNode(5))
+	results := withDice.RollResults(mockMaxRand{})
+	require.Equal(t, big.NewInt(20), results.Value)
+	require.Len(t, results.Dice, 1)
+}
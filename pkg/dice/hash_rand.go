@@ -0,0 +1,59 @@
+package dice
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+)
+
+// hashRand streams deterministic Intn output from a keyed hash in counter
+// mode, so that repeated calls with the same key produce the same
+// sequence - e.g. so a table lookup keyed by a monster ID always produces
+// the same drops.
+type hashRand struct {
+	mu      sync.Mutex
+	key     []byte
+	counter uint64
+}
+
+// HashRand creates a Rand which deterministically derives its output from
+// the given key by hashing it together with an incrementing counter using
+// SHA-256. The same key always produces the same sequence of Intn results.
+// It is safe for concurrent use.
+func HashRand(key []byte) Rand {
+	k := make([]byte, len(key))
+	copy(k, key)
+	return &hashRand{key: k}
+}
+
+// Intn returns the next int from [0, n) in this Rand's deterministic
+// sequence, using rejection sampling against the hash output to avoid
+// modulo bias.
+func (r *hashRand) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	limit := uint64(n)
+	threshold := ^uint64(0) - (^uint64(0) % limit)
+	for {
+		v := r.nextUint64()
+		if v < threshold {
+			return int(v % limit)
+		}
+	}
+}
+
+// nextUint64 hashes this Rand's key together with the current counter to
+// derive the next 8 bytes of output, then advances the counter.
+func (r *hashRand) nextUint64() uint64 {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], r.counter)
+	r.counter++
+
+	h := sha256.New()
+	h.Write(r.key)
+	h.Write(buf[:])
+	sum := h.Sum(nil)
+
+	return binary.BigEndian.Uint64(sum[:8])
+}
@@ -0,0 +1,198 @@
+package dice
+
+import (
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Hook is an interface used to observe the individual steps of a dice roll
+// as it happens. Implementations can use this to trace rolls, build
+// coverage/statistics reports, or capture a roll for later replay.
+type Hook interface {
+	// OnDie is called once for every individual die rolled, in roll order,
+	// with the die's position, number of sides, and resulting face value.
+	OnDie(index int, sides, value int)
+
+	// OnSort is called with the raw face values once they have been sorted
+	// in ascending order.
+	OnSort(raw []int)
+
+	// OnDrop is called with the dropped low and high values, if any.
+	OnDrop(low, high []int)
+
+	// OnResult is called with the final Results once the roll is complete.
+	OnResult(results *Results)
+}
+
+// NopHook is a Hook implementation whose methods do nothing. It is the
+// default Hook used when none is configured.
+type NopHook struct{}
+
+// OnDie implements Hook and does nothing.
+func (NopHook) OnDie(index, sides, value int) {}
+
+// OnSort implements Hook and does nothing.
+func (NopHook) OnSort(raw []int) {}
+
+// OnDrop implements Hook and does nothing.
+func (NopHook) OnDrop(low, high []int) {}
+
+// OnResult implements Hook and does nothing.
+func (NopHook) OnResult(results *Results) {}
+
+var defaultHook Hook = NopHook{}
+
+// SetDefaultHook sets the package-level Hook used by Dice.Roll, Dice.RollRand,
+// and Dice.RollResults. Passing nil restores the default NopHook.
+//
+// This is not safe to call concurrently with an in-progress roll.
+func SetDefaultHook(h Hook) {
+	if h == nil {
+		h = NopHook{}
+	}
+	defaultHook = h
+}
+
+// TraceHook is a Hook implementation which logs each roll event using its
+// Logger. NewTraceHook fills in log.Default() for a nil logger; the Logger
+// field of a TraceHook built directly is used as is and must not be nil.
+type TraceHook struct {
+	Logger *log.Logger
+}
+
+// NewTraceHook creates a TraceHook which logs to the given logger. If
+// logger is nil, log.Default() is used.
+func NewTraceHook(logger *log.Logger) *TraceHook {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &TraceHook{Logger: logger}
+}
+
+// OnDie logs the index, sides, and value of the die rolled.
+func (h *TraceHook) OnDie(index, sides, value int) {
+	h.Logger.Printf("dice: die %d (d%d) rolled %d", index, sides, value)
+}
+
+// OnSort logs the sorted raw roll values.
+func (h *TraceHook) OnSort(raw []int) {
+	h.Logger.Printf("dice: sorted rolls %v", raw)
+}
+
+// OnDrop logs the dropped low and high values.
+func (h *TraceHook) OnDrop(low, high []int) {
+	h.Logger.Printf("dice: dropped low=%v high=%v", low, high)
+}
+
+// OnResult logs the final result value.
+func (h *TraceHook) OnResult(results *Results) {
+	h.Logger.Printf("dice: result %s", results.Value.String())
+}
+
+// RecordingHook is a Hook implementation which captures every die's face
+// value as it is rolled, so that the roll can later be reproduced with a
+// ReplayRand built from Replay().
+type RecordingHook struct {
+	mu    sync.Mutex
+	Rolls []int
+}
+
+// NewRecordingHook creates an empty RecordingHook.
+func NewRecordingHook() *RecordingHook {
+	return &RecordingHook{}
+}
+
+// OnDie records the face value of the rolled die.
+func (h *RecordingHook) OnDie(index, sides, value int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Rolls = append(h.Rolls, value)
+}
+
+// OnSort implements Hook and does nothing.
+func (h *RecordingHook) OnSort(raw []int) {}
+
+// OnDrop implements Hook and does nothing.
+func (h *RecordingHook) OnDrop(low, high []int) {}
+
+// OnResult implements Hook and does nothing.
+func (h *RecordingHook) OnResult(results *Results) {}
+
+// Replay returns a ReplayRand which reproduces the recorded die values, in
+// the order they were rolled.
+func (h *RecordingHook) Replay() *ReplayRand {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	tape := make([]int, len(h.Rolls))
+	copy(tape, h.Rolls)
+	return NewReplayRand(tape)
+}
+
+// StatsHook is a Hook implementation which accumulates a histogram of face
+// values and a count of completed rolls across many rolls. It is safe for
+// concurrent use.
+type StatsHook struct {
+	mu      sync.Mutex
+	faces   map[int]map[int]int
+	results int
+}
+
+// NewStatsHook creates an empty StatsHook.
+func NewStatsHook() *StatsHook {
+	return &StatsHook{faces: make(map[int]map[int]int)}
+}
+
+// OnDie adds the rolled face value to this hook's histogram, keyed first by
+// the number of sides and then by the face value.
+func (h *StatsHook) OnDie(index, sides, value int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m, ok := h.faces[sides]
+	if !ok {
+		m = make(map[int]int)
+		h.faces[sides] = m
+	}
+	m[value]++
+}
+
+// OnSort implements Hook and does nothing.
+func (h *StatsHook) OnSort(raw []int) {}
+
+// OnDrop implements Hook and does nothing.
+func (h *StatsHook) OnDrop(low, high []int) {}
+
+// OnResult counts the completed roll.
+func (h *StatsHook) OnResult(results *Results) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.results++
+}
+
+// Histogram returns the number of times each face value has been rolled on
+// dice with the given number of sides.
+func (h *StatsHook) Histogram(sides int) map[int]int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m := h.faces[sides]
+	out := make(map[int]int, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// Results returns the number of completed rolls observed by this hook.
+func (h *StatsHook) Results() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.results
+}
+
+// String returns a human-readable summary of this StatsHook, suitable for
+// debugging.
+func (h *StatsHook) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return fmt.Sprintf("StatsHook{results: %d, faces: %v}", h.results, h.faces)
+}
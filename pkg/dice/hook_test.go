@@ -0,0 +1,81 @@
+package dice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHook(t *testing.T) {
+	t.Run("RollResultsHook", testRollResultsHook)
+	t.Run("DefaultHook", testDefaultHook)
+	t.Run("RecordingHookReplay", testRecordingHookReplay)
+	t.Run("StatsHook", testStatsHook)
+}
+
+// countingHook counts how many times each Hook method is invoked.
+type countingHook struct {
+	dice    int
+	sorts   int
+	drops   int
+	results int
+}
+
+func (h *countingHook) OnDie(index, sides, value int) { h.dice++ }
+func (h *countingHook) OnSort(raw []int)              { h.sorts++ }
+func (h *countingHook) OnDrop(low, high []int)        { h.drops++ }
+func (h *countingHook) OnResult(results *Results)     { h.results++ }
+
+func testRollResultsHook(t *testing.T) {
+	d, err := New(3, 20)
+	require.NoError(t, err)
+
+	h := &countingHook{}
+	results := d.RollResultsHook(mockMaxRand{}, h)
+	require.Equal(t, big.NewInt(60), results.Value)
+	require.Equal(t, 3, h.dice)
+	require.Equal(t, 1, h.sorts)
+	require.Equal(t, 1, h.drops)
+	require.Equal(t, 1, h.results)
+}
+
+func testDefaultHook(t *testing.T) {
+	d, err := New(2, 20)
+	require.NoError(t, err)
+
+	h := &countingHook{}
+	SetDefaultHook(h)
+	defer SetDefaultHook(nil)
+
+	d.RollResults(mockMaxRand{})
+	require.Equal(t, 2, h.dice)
+	require.Equal(t, 1, h.results)
+}
+
+func testRecordingHookReplay(t *testing.T) {
+	d, err := NewExt(5, 20, 2, 2)
+	require.NoError(t, err)
+
+	rec := NewRecordingHook()
+	original := d.RollResultsHook(&mockIterRand{}, rec)
+
+	replay := rec.Replay()
+	replayed := d.RollResultsHook(replay, nil)
+
+	require.Equal(t, original, replayed)
+	require.Equal(t, 0, replay.Remaining())
+}
+
+func testStatsHook(t *testing.T) {
+	d, err := New(1, 6)
+	require.NoError(t, err)
+
+	stats := NewStatsHook()
+	for i := 0; i < 3; i++ {
+		d.RollResultsHook(mockMaxRand{}, stats)
+	}
+
+	require.Equal(t, 3, stats.Results())
+	require.Equal(t, map[int]int{6: 3}, stats.Histogram(6))
+}
@@ -0,0 +1,343 @@
+package dice
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokDice
+	tokIdent
+	tokPlus
+	tokMinus
+	tokStar
+	tokSlash
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize splits a dice expression string into a flat list of tokens,
+// terminated by a tokEOF token.
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	runes := []rune(s)
+	i := 0
+	for i < len(runes) {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '+':
+			tokens = append(tokens, token{tokPlus, "+"})
+			i++
+		case c == '-':
+			tokens = append(tokens, token{tokMinus, "-"})
+			i++
+		case c == '*':
+			tokens = append(tokens, token{tokStar, "*"})
+			i++
+		case c == '/':
+			tokens = append(tokens, token{tokSlash, "/"})
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")"})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{tokComma, ","})
+			i++
+		case unicode.IsDigit(c):
+			tok, n, err := scanNumberOrDice(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i = n
+		case unicode.IsLetter(c):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			word := string(runes[start:i])
+			if strings.EqualFold(word, "d") || (len(word) > 0 && (word[0] == 'd' || word[0] == 'D') && len(word) > 1 && unicode.IsDigit(rune(word[1]))) {
+				tok, n, err := scanDiceFrom(runes, start, "1")
+				if err != nil {
+					return nil, err
+				}
+				tokens = append(tokens, tok)
+				i = n
+				continue
+			}
+			tokens = append(tokens, token{tokIdent, word})
+		default:
+			return nil, &UnexpectedCharacterError{C: c}
+		}
+	}
+	tokens = append(tokens, token{tokEOF, ""})
+	return tokens, nil
+}
+
+// scanNumberOrDice scans a run of digits starting at i. If the digits are
+// immediately followed by a 'd'/'D', the whole thing is parsed as a dice
+// specification token; otherwise a plain number token is returned.
+func scanNumberOrDice(runes []rune, i int) (token, int, error) {
+	start := i
+	for i < len(runes) && unicode.IsDigit(runes[i]) {
+		i++
+	}
+	number := string(runes[start:i])
+	if i < len(runes) && (runes[i] == 'd' || runes[i] == 'D') {
+		return scanDiceFrom(runes, i, number)
+	}
+	return token{tokNumber, number}, i, nil
+}
+
+// scanDiceFrom scans a dice specification whose number-of-dice portion has
+// already been consumed (and is passed as `number`), starting at the 'd'.
+func scanDiceFrom(runes []rune, i int, number string) (token, int, error) {
+	i++ // consume 'd'/'D'
+	sidesStart := i
+	for i < len(runes) && unicode.IsDigit(runes[i]) {
+		i++
+	}
+	if i == sidesStart {
+		return token{}, 0, &ExpectedSidesError{}
+	}
+	sides := string(runes[sidesStart:i])
+	text := number + "d" + sides
+	for i < len(runes) && (runes[i] == 'L' || runes[i] == 'l' || runes[i] == 'H' || runes[i] == 'h') {
+		letter := runes[i]
+		i++
+		countStart := i
+		for i < len(runes) && unicode.IsDigit(runes[i]) {
+			i++
+		}
+		if i == countStart {
+			return token{}, 0, &ExpectedDropCountError{}
+		}
+		text += string(unicode.ToUpper(letter)) + string(runes[countStart:i])
+	}
+	return token{tokDice, text}, i, nil
+}
+
+// parser is a recursive-descent parser over a flat token stream which
+// produces an Expression AST.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return tok
+}
+
+// Parse parses a dice expression string, such as "2d20L1 + 3d6 - 4" or
+// "1d100 + max(1d20, 1d20)", into an Expression AST which can be rolled
+// with Expression.Roll or Expression.RollResults.
+func Parse(s string) (Expression, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, &UnexpectedTokenError{Tok: p.peek().text}
+	}
+	return expr, nil
+}
+
+func (p *parser) parseExpr() (Expression, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokPlus, tokMinus:
+			op := BinOp(p.next().text[0])
+			right, err := p.parseTerm()
+			if err != nil {
+				return nil, err
+			}
+			left = NewBinOpNode(op, left, right)
+		default:
+			return left, nil
+		}
+	}
+}
+
+func (p *parser) parseTerm() (Expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokStar, tokSlash:
+			op := BinOp(p.next().text[0])
+			right, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			if op == OpDiv {
+				if c, ok := right.(*ConstNode); ok && c.Value.Sign() == 0 {
+					return nil, &DivisionByZeroError{}
+				}
+			}
+			left = NewBinOpNode(op, left, right)
+		default:
+			return left, nil
+		}
+	}
+}
+
+func (p *parser) parseUnary() (Expression, error) {
+	if p.peek().kind == tokMinus {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return NewBinOpNode(OpSub, NewConstNode(0), expr), nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expression, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokNumber:
+		p.next()
+		value, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, &InvalidNumberError{S: tok.text}
+		}
+		return NewConstNode(value), nil
+	case tokDice:
+		p.next()
+		d, err := parseDiceSpec(tok.text)
+		if err != nil {
+			return nil, err
+		}
+		return NewDiceNode(d), nil
+	case tokLParen:
+		p.next()
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, &ExpectedTokenError{Tok: ")"}
+		}
+		p.next()
+		return expr, nil
+	case tokIdent:
+		p.next()
+		if !knownFunctions[tok.text] {
+			return nil, &UnknownFunctionError{Name: tok.text}
+		}
+		if p.peek().kind != tokLParen {
+			return nil, &ExpectedTokenError{Tok: "("}
+		}
+		p.next()
+		var args []Expression
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind == tokComma {
+				p.next()
+				continue
+			}
+			break
+		}
+		if p.peek().kind != tokRParen {
+			return nil, &ExpectedTokenError{Tok: ")"}
+		}
+		p.next()
+		return NewFuncNode(tok.text, args...), nil
+	default:
+		return nil, &UnexpectedTokenError{Tok: tok.text}
+	}
+}
+
+// parseDiceSpec parses a normalized dice token (e.g. "2d20L1H1") into a
+// Dice instance.
+func parseDiceSpec(text string) (*Dice, error) {
+	lower := strings.ToLower(text)
+	dIdx := strings.IndexByte(lower, 'd')
+	if dIdx < 0 {
+		return nil, &InvalidDiceSpecError{Spec: text}
+	}
+	number, err := strconv.Atoi(text[:dIdx])
+	if err != nil {
+		return nil, &InvalidDiceSpecError{Spec: text}
+	}
+
+	rest := text[dIdx+1:]
+	lIdx := strings.IndexByte(rest, 'L')
+	hIdx := strings.IndexByte(rest, 'H')
+
+	end := len(rest)
+	if lIdx >= 0 && lIdx < end {
+		end = lIdx
+	}
+	if hIdx >= 0 && hIdx < end {
+		end = hIdx
+	}
+	sides, err := strconv.Atoi(rest[:end])
+	if err != nil {
+		return nil, &InvalidDiceSpecError{Spec: text}
+	}
+
+	droplow, drophigh := 0, 0
+	if lIdx >= 0 {
+		lend := len(rest)
+		if hIdx > lIdx {
+			lend = hIdx
+		}
+		droplow, err = strconv.Atoi(rest[lIdx+1 : lend])
+		if err != nil {
+			return nil, &InvalidDiceSpecError{Spec: text}
+		}
+	}
+	if hIdx >= 0 {
+		hend := len(rest)
+		if lIdx > hIdx {
+			hend = lIdx
+		}
+		drophigh, err = strconv.Atoi(rest[hIdx+1 : hend])
+		if err != nil {
+			return nil, &InvalidDiceSpecError{Spec: text}
+		}
+	}
+
+	return NewExt(number, sides, droplow, drophigh)
+}
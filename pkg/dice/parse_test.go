@@ -0,0 +1,136 @@
+package dice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("SimpleDice", testParseSimpleDice)
+	t.Run("DropLow", testParseDropLow)
+	t.Run("DropHigh", testParseDropHigh)
+	t.Run("DropBoth", testParseDropBoth)
+	t.Run("BareD", testParseBareD)
+	t.Run("Arithmetic", testParseArithmetic)
+	t.Run("Parens", testParseParens)
+	t.Run("Func", testParseFunc)
+	t.Run("RoundTrip", testParseRoundTrip)
+	t.Run("StringRoundTrip", testParseStringRoundTrip)
+	t.Run("Errors", testParseErrors)
+}
+
+func testParseSimpleDice(t *testing.T) {
+	expr, err := Parse("2d20")
+	require.NoError(t, err)
+	n, ok := expr.(*DiceNode)
+	require.True(t, ok)
+	require.Equal(t, &Dice{Number: 2, Sides: 20}, n.Dice)
+}
+
+func testParseDropLow(t *testing.T) {
+	expr, err := Parse("4d6L1")
+	require.NoError(t, err)
+	n, ok := expr.(*DiceNode)
+	require.True(t, ok)
+	require.Equal(t, &Dice{Number: 4, Sides: 6, DropLow: 1}, n.Dice)
+}
+
+func testParseDropHigh(t *testing.T) {
+	expr, err := Parse("2d20H1")
+	require.NoError(t, err)
+	n, ok := expr.(*DiceNode)
+	require.True(t, ok)
+	require.Equal(t, &Dice{Number: 2, Sides: 20, DropHigh: 1}, n.Dice)
+}
+
+func testParseDropBoth(t *testing.T) {
+	expr, err := Parse("5d20L1H1")
+	require.NoError(t, err)
+	n, ok := expr.(*DiceNode)
+	require.True(t, ok)
+	require.Equal(t, &Dice{Number: 5, Sides: 20, DropLow: 1, DropHigh: 1}, n.Dice)
+}
+
+func testParseBareD(t *testing.T) {
+	expr, err := Parse("d20")
+	require.NoError(t, err)
+	n, ok := expr.(*DiceNode)
+	require.True(t, ok)
+	require.Equal(t, &Dice{Number: 1, Sides: 20}, n.Dice)
+}
+
+func testParseArithmetic(t *testing.T) {
+	expr, err := Parse("2d20L1 + 3d6 - 4")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(20+3*6-4), expr.Roll(mockMaxRand{}))
+}
+
+func testParseParens(t *testing.T) {
+	expr, err := Parse("(4d6L1)*2")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3*6*2), expr.Roll(mockMaxRand{}))
+}
+
+func testParseFunc(t *testing.T) {
+	expr, err := Parse("1d100 + max(1d20, 1d20)")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100+20), expr.Roll(mockMaxRand{}))
+}
+
+func testParseRoundTrip(t *testing.T) {
+	d, err := NewExt(5, 20, 1, 1)
+	require.NoError(t, err)
+
+	expr, err := Parse(d.String())
+	require.NoError(t, err)
+	n, ok := expr.(*DiceNode)
+	require.True(t, ok)
+	require.Equal(t, d, n.Dice)
+}
+
+func testParseStringRoundTrip(t *testing.T) {
+	expr, err := Parse("(1+2)*3")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(9), expr.Roll(mockMaxRand{}))
+
+	reparsed, err := Parse(expr.String())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(9), reparsed.Roll(mockMaxRand{}))
+}
+
+func testParseErrors(t *testing.T) {
+	t.Run("UnexpectedCharacter", func(t *testing.T) {
+		_, err := Parse("2d20 & 3")
+		require.Error(t, err)
+	})
+	t.Run("UnbalancedParens", func(t *testing.T) {
+		_, err := Parse("(2d20 + 3")
+		require.Error(t, err)
+	})
+	t.Run("MissingSides", func(t *testing.T) {
+		_, err := Parse("2d")
+		require.Error(t, err)
+	})
+	t.Run("TrailingTokens", func(t *testing.T) {
+		_, err := Parse("2d20 3d6")
+		require.Error(t, err)
+	})
+	t.Run("InvalidDiceSpec", func(t *testing.T) {
+		_, err := Parse("2d1")
+		require.Error(t, err)
+	})
+	t.Run("UnknownFunction", func(t *testing.T) {
+		_, err := Parse("foo(1, 2)")
+		require.ErrorIs(t, err, ErrUnknownFunction)
+	})
+	t.Run("UnknownFunctionTypo", func(t *testing.T) {
+		_, err := Parse("mx(1d20, 1d20)")
+		require.ErrorIs(t, err, ErrUnknownFunction)
+	})
+	t.Run("DivisionByZero", func(t *testing.T) {
+		_, err := Parse("5/0")
+		require.ErrorIs(t, err, ErrDivisionByZero)
+	})
+}
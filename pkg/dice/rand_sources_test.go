@@ -0,0 +1,78 @@
+package dice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandSources(t *testing.T) {
+	t.Run("CryptoRand", testCryptoRand)
+	t.Run("SeededRand", testSeededRand)
+	t.Run("HashRand", testHashRand)
+	t.Run("RecordingRand", testRecordingRand)
+}
+
+func testCryptoRand(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		v := CryptoRand.Intn(20)
+		require.GreaterOrEqual(t, v, 0)
+		require.Less(t, v, 20)
+	}
+}
+
+func testSeededRand(t *testing.T) {
+	a := SeededRand(42)
+	b := SeededRand(42)
+
+	for i := 0; i < 50; i++ {
+		require.Equal(t, a.Intn(100), b.Intn(100))
+	}
+
+	c := SeededRand(43)
+	different := false
+	for i := 0; i < 50; i++ {
+		if a.Intn(1000) != c.Intn(1000) {
+			different = true
+			break
+		}
+	}
+	require.True(t, different, "expected different seeds to diverge")
+}
+
+func testHashRand(t *testing.T) {
+	a := HashRand([]byte("goblin-17"))
+	b := HashRand([]byte("goblin-17"))
+
+	for i := 0; i < 50; i++ {
+		require.Equal(t, a.Intn(100), b.Intn(100))
+	}
+
+	c := HashRand([]byte("goblin-18"))
+	different := false
+	for i := 0; i < 50; i++ {
+		if a.Intn(1000) != c.Intn(1000) {
+			different = true
+			break
+		}
+	}
+	require.True(t, different, "expected different keys to diverge")
+}
+
+func testRecordingRand(t *testing.T) {
+	source := SeededRand(7)
+	recorder := NewRecordingRand(source)
+
+	var original []int
+	for i := 0; i < 20; i++ {
+		original = append(original, recorder.Intn(100))
+	}
+
+	replay := recorder.Replay()
+	require.Equal(t, len(original), replay.Remaining())
+	for _, v := range original {
+		require.Equal(t, v, replay.Intn(100))
+	}
+	require.Equal(t, 0, replay.Remaining())
+	require.Panics(t, func() { replay.Intn(100) })
+}
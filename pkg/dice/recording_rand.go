@@ -0,0 +1,84 @@
+package dice
+
+import "sync"
+
+// RecordingRand wraps another Rand implementation and records every value it
+// returns from Intn, in call order, so that source's raw output stream can
+// later be reproduced exactly with Replay - unlike RecordingHook, which only
+// captures the face values Dice.RollResultsHook observes, RecordingRand
+// captures the underlying Rand's output directly and so can wrap any source,
+// including CryptoRand. It is safe for concurrent use.
+type RecordingRand struct {
+	mu     sync.Mutex
+	Source Rand
+	tape   []int
+}
+
+// NewRecordingRand creates a RecordingRand which records and forwards every
+// Intn call to source.
+func NewRecordingRand(source Rand) *RecordingRand {
+	return &RecordingRand{Source: source}
+}
+
+// Intn forwards to the wrapped source and records the result before
+// returning it.
+func (r *RecordingRand) Intn(n int) int {
+	v := r.Source.Intn(n)
+	r.mu.Lock()
+	r.tape = append(r.tape, v)
+	r.mu.Unlock()
+	return v
+}
+
+// Tape returns a copy of the sequence of Intn results recorded so far, in
+// call order.
+func (r *RecordingRand) Tape() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tape := make([]int, len(r.tape))
+	copy(tape, r.tape)
+	return tape
+}
+
+// Replay returns a Rand which deterministically reproduces this recording's
+// tape instead of consulting the wrapped source again.
+func (r *RecordingRand) Replay() *RawReplayRand {
+	return NewRawReplayRand(r.Tape())
+}
+
+// RawReplayRand is a Rand implementation which replays a fixed, pre-recorded
+// sequence of raw Intn results, typically captured by a RecordingRand
+// wrapping a non-deterministic source such as CryptoRand. Unlike ReplayRand,
+// which stores die face values and adjusts them into [0, n), RawReplayRand
+// returns each recorded value verbatim. It is safe for concurrent use.
+type RawReplayRand struct {
+	mu   sync.Mutex
+	tape []int
+	pos  int
+}
+
+// NewRawReplayRand creates a RawReplayRand which replays the values in tape,
+// in order, one per call to Intn.
+func NewRawReplayRand(tape []int) *RawReplayRand {
+	return &RawReplayRand{tape: tape}
+}
+
+// Intn returns the next recorded value from the tape. It panics if the tape
+// has been exhausted.
+func (r *RawReplayRand) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pos >= len(r.tape) {
+		panic("dice: RawReplayRand tape exhausted")
+	}
+	v := r.tape[r.pos]
+	r.pos++
+	return v
+}
+
+// Remaining returns the number of recorded values left to replay.
+func (r *RawReplayRand) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.tape) - r.pos
+}
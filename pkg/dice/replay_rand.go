@@ -0,0 +1,33 @@
+package dice
+
+// ReplayRand is a Rand implementation which replays a fixed, pre-recorded
+// sequence of die face values instead of generating new random values. It
+// is typically constructed from a RecordingHook's captured rolls so that a
+// previous session can be deterministically reproduced.
+type ReplayRand struct {
+	tape []int
+	pos  int
+}
+
+// NewReplayRand creates a ReplayRand which replays the face values in tape,
+// in order, one per call to Intn.
+func NewReplayRand(tape []int) *ReplayRand {
+	return &ReplayRand{tape: tape}
+}
+
+// Intn returns the next recorded face value from the tape, adjusted into
+// the [0, n) range expected by the Rand interface. It panics if the tape
+// has been exhausted.
+func (r *ReplayRand) Intn(n int) int {
+	if r.pos >= len(r.tape) {
+		panic("dice: ReplayRand tape exhausted")
+	}
+	v := r.tape[r.pos] - 1
+	r.pos++
+	return v
+}
+
+// Remaining returns the number of recorded values left to replay.
+func (r *ReplayRand) Remaining() int {
+	return len(r.tape) - r.pos
+}
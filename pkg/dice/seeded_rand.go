@@ -0,0 +1,28 @@
+package dice
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// seededRand wraps a per-instance math/rand.Rand behind a mutex, since
+// *math/rand.Rand is not itself safe for concurrent use.
+type seededRand struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// SeededRand creates a Rand backed by a per-instance pseudo-random source
+// seeded with the given value, so that a roll sequence can be reproduced
+// deterministically - useful for tests and for replaying a shared session.
+func SeededRand(seed uint64) Rand {
+	return &seededRand{rng: rand.New(rand.NewSource(int64(seed)))}
+}
+
+// Intn returns a random int from [0, n), reproducible from this Rand's
+// seed.
+func (r *seededRand) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.Intn(n)
+}